@@ -0,0 +1,112 @@
+package gowalker
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// These tests are the compatibility matrix for Crawl/ObjMeta: pre-existing
+// callers that still pass a positional UserFunc and hold onto an *ObjMeta
+// should keep compiling and behaving the same now that both are thin
+// wrappers around Walk/FieldMeta.
+
+type crawlTestStruct struct {
+	Field1    int
+	Field2    string
+	SubStruct struct {
+		SubField1 float32
+	}
+}
+
+func TestCrawlVisitsEveryField(t *testing.T) {
+	obj := &crawlTestStruct{Field1: 1, Field2: "two"}
+
+	var visited sync.Map
+	fn := func(v reflect.Value, meta ObjMeta) error {
+		visited.Store(meta.Path, struct{}{})
+		return nil
+	}
+
+	root, err := Crawl(obj, fn)
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+	if root == nil {
+		t.Fatal("Crawl() root = nil")
+	}
+
+	for _, want := range []string{
+		"*gowalker.crawlTestStruct",
+		"*gowalker.crawlTestStruct.*",
+		"*gowalker.crawlTestStruct.*.Field1",
+		"*gowalker.crawlTestStruct.*.Field2",
+		"*gowalker.crawlTestStruct.*.SubStruct",
+		"*gowalker.crawlTestStruct.*.SubStruct.SubField1",
+	} {
+		if _, ok := visited.Load(want); !ok {
+			t.Errorf("Crawl() did not visit %q", want)
+		}
+	}
+}
+
+func TestCrawlPropagatesUserFuncError(t *testing.T) {
+	obj := &crawlTestStruct{}
+	boom := errors.New("boom")
+
+	_, err := Crawl(obj, func(v reflect.Value, meta ObjMeta) error {
+		if meta.Name == "int" {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("Crawl() error = %v, want %v", err, boom)
+	}
+}
+
+func TestCrawlHonorsOptionsLikeWalk(t *testing.T) {
+	obj := &crawlTestStruct{}
+
+	var visited sync.Map
+	fn := func(v reflect.Value, meta ObjMeta) error {
+		visited.Store(meta.Path, struct{}{})
+		return nil
+	}
+
+	if _, err := Crawl(obj, fn, MaxDepth(1)); err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+
+	if _, ok := visited.Load("*gowalker.crawlTestStruct.*.SubStruct.SubField1"); ok {
+		t.Error("Crawl() visited SubField1 despite MaxDepth(1)")
+	}
+}
+
+// TestObjMetaIsUsableWhereverFieldMetaIs covers a caller that predates
+// FieldMeta and still declares its own *ObjMeta-typed variables and walks
+// Children/Parent by hand; this only compiles because ObjMeta is a type
+// alias for FieldMeta.
+func TestObjMetaIsUsableWhereverFieldMetaIs(t *testing.T) {
+	obj := &crawlTestStruct{}
+
+	var root *ObjMeta
+	root, err := Crawl(obj, func(v reflect.Value, meta ObjMeta) error { return nil })
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+
+	var field *FieldMeta = root // ObjMeta and FieldMeta are interchangeable
+	if field.Name != root.Name {
+		t.Errorf("ObjMeta and FieldMeta diverged: %q vs %q", field.Name, root.Name)
+	}
+	if len(root.Children) == 0 {
+		t.Error("expected root.Children to be populated")
+	}
+	for _, child := range root.Children {
+		if child.Parent != root {
+			t.Errorf("child %q.Parent = %v, want root", child.Path, child.Parent)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gowalker
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldPlan is the precomputed description of a single struct field used by
+// the hot walk loop. It captures everything that can be derived from the
+// reflect.Type alone so walkRecursive never needs to call t.Field(i) or
+// t.FieldByName during a walk.
+type fieldPlan struct {
+	index int
+	name  string
+	tag   reflect.StructTag
+	field reflect.StructField
+}
+
+// typePlan is the cached, per-reflect.Type analysis of a struct: its ordered,
+// visitable fields. Non-struct types get an empty typePlan; walkRecursive
+// falls back to its existing switch for slices, maps, pointers, etc.
+type typePlan struct {
+	fields []fieldPlan
+}
+
+// typePlanCache is a process-wide cache of typePlan by reflect.Type, mirroring
+// the cached-struct-metadata approach used by go-playground/validator and
+// jmoiron/sqlx's reflectx: every type is analyzed at most once for the
+// lifetime of the process.
+var typePlanCache sync.Map // map[reflect.Type]*typePlan
+
+// getTypePlan returns the cached typePlan for t, building and storing one if
+// this is the first time t has been seen.
+func getTypePlan(t reflect.Type) *typePlan {
+	if cached, ok := typePlanCache.Load(t); ok {
+		return cached.(*typePlan)
+	}
+
+	plan := buildTypePlan(t)
+
+	actual, _ := typePlanCache.LoadOrStore(t, plan)
+	return actual.(*typePlan)
+}
+
+// buildTypePlan walks t's fields once and captures the reflection metadata
+// the hot loop needs. Filters that only depend on type/tag (tagFilter,
+// typeFilter, includePrivate) are evaluated against this cached data at walk
+// time rather than being baked in here, since they vary per Walk call;
+// OnlySettable depends on a runtime value (addressability) and stays a
+// per-value check in walkRecursive. A MetaFilter set with WithMetaFilter is
+// stored in walkSettings but is not applied by walkRecursive.
+func buildTypePlan(t reflect.Type) *typePlan {
+	if t.Kind() != reflect.Struct {
+		return &typePlan{}
+	}
+
+	n := t.NumField()
+	plan := &typePlan{fields: make([]fieldPlan, n)}
+	for i := 0; i < n; i++ {
+		sf := t.Field(i)
+		plan.fields[i] = fieldPlan{
+			index: i,
+			name:  sf.Name,
+			tag:   sf.Tag,
+			field: sf,
+		}
+	}
+	return plan
+}
+
+// ResetCache clears the process-wide type-plan cache. Intended for use in
+// tests that redefine types between runs or that need to benchmark a cold
+// cache.
+func ResetCache() {
+	typePlanCache = sync.Map{}
+}
@@ -0,0 +1,37 @@
+package gowalker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetTypePlanIsCachedPerType(t *testing.T) {
+	ResetCache()
+
+	typ := reflect.TypeOf(BenchStruct{})
+
+	first := getTypePlan(typ)
+	second := getTypePlan(typ)
+
+	if first != second {
+		t.Fatalf("getTypePlan() returned different plans for the same type")
+	}
+
+	if len(first.fields) != typ.NumField() {
+		t.Fatalf("typePlan has %d fields, want %d", len(first.fields), typ.NumField())
+	}
+}
+
+func TestResetCacheForcesRebuild(t *testing.T) {
+	ResetCache()
+
+	typ := reflect.TypeOf(BenchStruct{})
+	first := getTypePlan(typ)
+
+	ResetCache()
+	second := getTypePlan(typ)
+
+	if first == second {
+		t.Fatalf("ResetCache() did not force a new typePlan to be built")
+	}
+}
@@ -0,0 +1,337 @@
+// Package validate layers struct-tag driven validation on top of gowalker's
+// Walk. A `validate:"..."` tag is a comma-separated list of rules, each of
+// which may itself be several '|'-separated alternatives (an OR, satisfied if
+// any alternative passes); a rule may carry a parameter after '=', e.g.
+// "min=3" or "oneof=admin member guest". The special rule "dive" applies the
+// remainder of the tag to each element of a slice or map instead of to the
+// slice/map value itself.
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	gowalker "github.com/ashtonian/struct-dive"
+)
+
+// ValidatorFunc checks whether v satisfies a single validation rule. param is
+// the text following '=' in the tag (e.g. "3" for min=3, "a b c" for
+// oneof=a b c), or the empty string for rules that take no parameter.
+type ValidatorFunc func(v reflect.Value, param string) bool
+
+// FieldError describes one validation rule that failed for one field.
+type FieldError struct {
+	Path  string      // the field's path, as rendered by the walk that found it
+	Tag   string      // the rule name that failed, e.g. "min"
+	Param string      // the rule's parameter, e.g. "3"; empty if it takes none
+	Value interface{} // the value that failed validation
+}
+
+// Error renders e using the current messageFormatter, so FieldError can be
+// used directly wherever an error is expected.
+func (e FieldError) Error() string {
+	messageFormatterMu.RLock()
+	defer messageFormatterMu.RUnlock()
+	return messageFormatter(e)
+}
+
+func defaultMessage(e FieldError) string {
+	if e.Param != "" {
+		return fmt.Sprintf("%s failed on the %q tag (param %q)", e.Path, e.Tag, e.Param)
+	}
+	return fmt.Sprintf("%s failed on the %q tag", e.Path, e.Tag)
+}
+
+var (
+	messageFormatterMu sync.RWMutex
+	messageFormatter   = defaultMessage
+)
+
+// WithMessageFormatter replaces the formatter FieldError.Error uses to render
+// its message, e.g. to translate messages for i18n. Like RegisterValidator,
+// it is a process-wide setting rather than a per-call option, since Validate
+// takes no options of its own.
+func WithMessageFormatter(fn func(FieldError) string) {
+	messageFormatterMu.Lock()
+	defer messageFormatterMu.Unlock()
+	messageFormatter = fn
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string]ValidatorFunc{
+		"required": validateRequired,
+		"email":    validateEmail,
+		"min":      validateMin,
+		"max":      validateMax,
+		"len":      validateLen,
+		"oneof":    validateOneof,
+	}
+)
+
+// RegisterValidator adds or replaces the ValidatorFunc used for name in
+// `validate` tags. It is process-wide, so it is typically called during
+// package init rather than per Validate call.
+func RegisterValidator(name string, fn ValidatorFunc) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators[name] = fn
+}
+
+func lookupValidator(name string) (ValidatorFunc, bool) {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	fn, ok := validators[name]
+	return fn, ok
+}
+
+// rule is a single parsed clause, e.g. "min=3" becomes rule{name: "min", param: "3"}.
+type rule struct {
+	name  string
+	param string
+}
+
+// ruleGroup is one comma-separated clause of a tag, which may itself be
+// several '|'-separated alternatives; the group passes if any alternative does.
+type ruleGroup []rule
+
+// parsedTag is a `validate` tag split into the rules that apply to the
+// tagged value itself (self) and, if the tag contains "dive", the rules that
+// apply to each element when the value is a slice or map (dive).
+type parsedTag struct {
+	self    []ruleGroup
+	dive    []ruleGroup
+	hasDive bool
+}
+
+// tagCache caches parsedTag by the raw tag text, so a `validate` tag shared
+// by many instances of the same struct (or repeated across a slice of
+// structs) is only parsed once, mirroring the per-reflect.Type type-plan
+// cache gowalker itself uses for field metadata.
+var tagCache sync.Map // map[string]parsedTag
+
+func getParsedTag(tag string) parsedTag {
+	if cached, ok := tagCache.Load(tag); ok {
+		return cached.(parsedTag)
+	}
+
+	parsed := parseTag(tag)
+
+	actual, _ := tagCache.LoadOrStore(tag, parsed)
+	return actual.(parsedTag)
+}
+
+func parseTag(tag string) parsedTag {
+	var parsed parsedTag
+	if tag == "" {
+		return parsed
+	}
+
+	diving := false
+	for _, clause := range strings.Split(tag, ",") {
+		if clause == "dive" {
+			parsed.hasDive = true
+			diving = true
+			continue
+		}
+
+		var group ruleGroup
+		for _, alt := range strings.Split(clause, "|") {
+			name, param, _ := strings.Cut(alt, "=")
+			if name == "" {
+				continue
+			}
+			group = append(group, rule{name: name, param: param})
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		if diving {
+			parsed.dive = append(parsed.dive, group)
+		} else {
+			parsed.self = append(parsed.self, group)
+		}
+	}
+
+	return parsed
+}
+
+// Validate walks obj exactly as gowalker.Walk does - including its cycle and
+// max-depth handling - and evaluates each field's `validate` struct tag,
+// returning one FieldError per failed rule. "dive" additionally applies the
+// remainder of a slice or map field's tag to each of its elements.
+func Validate(obj interface{}) ([]FieldError, error) {
+	var fieldErrs []FieldError
+
+	_, _, err := gowalker.Walk(obj, gowalker.WithUserFunc(func(v reflect.Value, meta gowalker.FieldMeta) error {
+		if tag, ok := meta.Tag.Lookup("validate"); ok {
+			errs, err := evalTag(getParsedTag(tag).self, v, meta.Path)
+			if err != nil {
+				return err
+			}
+			fieldErrs = append(fieldErrs, errs...)
+		}
+
+		if meta.Parent == nil {
+			return nil
+		}
+
+		ptag, ok := meta.Parent.Tag.Lookup("validate")
+		if !ok {
+			return nil
+		}
+
+		parsed := getParsedTag(ptag)
+		if !parsed.hasDive {
+			return nil
+		}
+
+		errs, err := evalTag(parsed.dive, v, meta.Path)
+		if err != nil {
+			return err
+		}
+		fieldErrs = append(fieldErrs, errs...)
+
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return fieldErrs, nil
+}
+
+func evalTag(groups []ruleGroup, v reflect.Value, path string) ([]FieldError, error) {
+	var errs []FieldError
+	for _, group := range groups {
+		fieldErr, err := evalGroup(v, path, group)
+		if err != nil {
+			return nil, err
+		}
+		if fieldErr != nil {
+			errs = append(errs, *fieldErr)
+		}
+	}
+	return errs, nil
+}
+
+// evalGroup runs the '|'-joined alternatives in group against v, returning
+// nil as soon as one passes. If every alternative fails, it reports the
+// first one.
+func evalGroup(v reflect.Value, path string, group ruleGroup) (*FieldError, error) {
+	var failed *FieldError
+	for _, r := range group {
+		fn, ok := lookupValidator(r.name)
+		if !ok {
+			return nil, fmt.Errorf("gowalker/validate: unknown validator %q", r.name)
+		}
+		if fn(v, r.param) {
+			return nil, nil
+		}
+		if failed == nil {
+			failed = &FieldError{Path: path, Tag: r.name, Param: r.param, Value: safeInterface(v)}
+		}
+	}
+	return failed, nil
+}
+
+func safeInterface(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func validateRequired(v reflect.Value, param string) bool {
+	return v.IsValid() && !v.IsZero()
+}
+
+func validateEmail(v reflect.Value, param string) bool {
+	if v.Kind() != reflect.String {
+		return false
+	}
+	_, err := mail.ParseAddress(v.String())
+	return err == nil
+}
+
+func validateMin(v reflect.Value, param string) bool {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return float64(len(v.String())) >= n
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()) >= n
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()) >= n
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()) >= n
+	case reflect.Float32, reflect.Float64:
+		return v.Float() >= n
+	default:
+		return false
+	}
+}
+
+func validateMax(v reflect.Value, param string) bool {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return float64(len(v.String())) <= n
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()) <= n
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()) <= n
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()) <= n
+	case reflect.Float32, reflect.Float64:
+		return v.Float() <= n
+	default:
+		return false
+	}
+}
+
+func validateLen(v reflect.Value, param string) bool {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return len(v.String()) == n
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == n
+	default:
+		return false
+	}
+}
+
+func validateOneof(v reflect.Value, param string) bool {
+	var s string
+	switch v.Kind() {
+	case reflect.String:
+		s = v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s = strconv.FormatUint(v.Uint(), 10)
+	default:
+		return false
+	}
+	for _, opt := range strings.Fields(param) {
+		if opt == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type address struct {
+	City string `validate:"required"`
+	Zip  string `validate:"required,len=5"`
+}
+
+type person struct {
+	Name    string   `validate:"required,min=2"`
+	Email   string   `validate:"email"`
+	Age     int      `validate:"min=0,max=130"`
+	Role    string   `validate:"oneof=admin member guest"`
+	Address *address `validate:"required"`
+	Tags    []string `validate:"dive,min=1"`
+}
+
+func hasFieldError(errs []FieldError, pathSuffix, tag string) bool {
+	for _, e := range errs {
+		if strings.HasSuffix(e.Path, pathSuffix) && e.Tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateNestedAndPointerFields(t *testing.T) {
+	p := &person{
+		Name:    "Al",
+		Email:   "not-an-email",
+		Age:     200,
+		Role:    "owner",
+		Address: &address{City: "", Zip: "123"},
+		Tags:    []string{"ok", ""},
+	}
+
+	errs, err := Validate(p)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	cases := []struct {
+		pathSuffix, tag string
+	}{
+		{".Email", "email"},
+		{".Age", "max"},
+		{".Role", "oneof"},
+		{".Address.*.City", "required"},
+		{".Address.*.Zip", "len"},
+	}
+	for _, c := range cases {
+		if !hasFieldError(errs, c.pathSuffix, c.tag) {
+			t.Errorf("missing FieldError for path suffix %q, tag %q; got %v", c.pathSuffix, c.tag, errs)
+		}
+	}
+
+	if hasFieldError(errs, ".Name", "required") || hasFieldError(errs, ".Name", "min") {
+		t.Errorf("did not expect Name to fail validation: %v", errs)
+	}
+	if hasFieldError(errs, ".Address", "required") {
+		t.Errorf("Address is non-nil, did not expect it to fail required: %v", errs)
+	}
+}
+
+func TestValidateDiveAppliesRuleToEachElement(t *testing.T) {
+	p := &person{
+		Name: "Al", Email: "a@b.com", Age: 1, Role: "admin",
+		Address: &address{City: "x", Zip: "12345"},
+		Tags:    []string{"ok", ""},
+	}
+
+	errs, err := Validate(p)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if !hasFieldError(errs, "[1]", "min") {
+		t.Errorf("expected dive to flag the empty Tags element, got %v", errs)
+	}
+	if hasFieldError(errs, "[0]", "min") {
+		t.Errorf("did not expect the non-empty Tags element to fail, got %v", errs)
+	}
+}
+
+func TestValidateOneOf(t *testing.T) {
+	type widget struct {
+		Kind string `validate:"oneof=circle square triangle"`
+	}
+
+	errs, err := Validate(&widget{Kind: "hexagon"})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !hasFieldError(errs, ".Kind", "oneof") {
+		t.Errorf("expected oneof failure, got %v", errs)
+	}
+
+	errs, err = Validate(&widget{Kind: "circle"})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors for a valid enum value, got %v", errs)
+	}
+}
+
+type cycleNode struct {
+	Label string `validate:"required"`
+	Next  *cycleNode
+}
+
+func TestValidateCycleSafety(t *testing.T) {
+	n := &cycleNode{Label: "ok"}
+	n.Next = n
+
+	errs, err := Validate(n)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors on a valid cyclic value: %v", errs)
+	}
+}
+
+func TestRegisterValidatorAddsCustomRule(t *testing.T) {
+	RegisterValidator("even", func(v reflect.Value, param string) bool {
+		return v.Kind() == reflect.Int && v.Int()%2 == 0
+	})
+
+	type box struct {
+		N int `validate:"even"`
+	}
+
+	errs, err := Validate(&box{N: 3})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !hasFieldError(errs, ".N", "even") {
+		t.Errorf("expected custom validator to flag an odd value, got %v", errs)
+	}
+
+	errs, err = Validate(&box{N: 4})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors for an even value, got %v", errs)
+	}
+}
+
+func TestWithMessageFormatterOverridesErrorText(t *testing.T) {
+	orig := messageFormatter
+	defer func() { messageFormatter = orig }()
+
+	WithMessageFormatter(func(e FieldError) string {
+		return "custom: " + e.Tag
+	})
+
+	type box struct {
+		N int `validate:"min=5"`
+	}
+
+	errs, err := Validate(&box{N: 1})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if got := errs[0].Error(); got != "custom: min" {
+		t.Errorf("Error() = %q, want %q", got, "custom: min")
+	}
+}
+
+func TestMessageFormatterConcurrentAccessIsRaceFree(t *testing.T) {
+	orig := messageFormatter
+	defer func() { messageFormatter = orig }()
+
+	fe := FieldError{Path: "N", Tag: "min", Param: "5"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = fe.Error()
+		}()
+		go func(i int) {
+			defer wg.Done()
+			WithMessageFormatter(func(e FieldError) string {
+				return fmt.Sprintf("formatter %d: %s", i, e.Tag)
+			})
+		}(i)
+	}
+	wg.Wait()
+}
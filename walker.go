@@ -1,9 +1,9 @@
 package gowalker
 
 import (
-	"fmt"
 	"reflect"
 	"sync"
+	"unsafe"
 )
 
 type UserFunc func(v reflect.Value, meta FieldMeta) error
@@ -20,16 +20,109 @@ type FieldMeta struct {
 	Path      string
 	Parent    *FieldMeta
 	Children  []*FieldMeta
+	// Tag is the struct tag of the field this node was reached through, as
+	// seen by its parent struct. It is empty for the root node and for
+	// pointer/interface, slice/array and map elements, which have no tag of
+	// their own.
+	Tag reflect.StructTag
+	// CycleRef points at the FieldMeta previously emitted for this same
+	// (reflect.Type, pointer) identity when the walk encounters it again.
+	// Nodes with a non-nil CycleRef are not descended into further, so
+	// Children is left empty on them.
+	CycleRef *FieldMeta
 }
 
 type walkSettings struct {
-	maxDepth       int
-	includePrivate bool
-	onlySettable   bool
-	tagFilter      TagFilter
-	typeFilter     TypeFilter
-	metaFilter     MetaFilter
-	userFuncs      []UserFunc
+	maxDepth          int
+	includePrivate    bool
+	onlySettable      bool
+	tagFilter         TagFilter
+	typeFilter        TypeFilter
+	metaFilter        MetaFilter
+	userFuncs         []UserFunc
+	maxVisits         int
+	parallelism       int
+	parallelThreshold int
+	orderedChildren   bool
+	pathEncoder       PathEncoder
+}
+
+// walkState holds the mutable, shared-across-goroutines bookkeeping for a
+// single Walk call: cycle-detection identities and the total visit count.
+// Plain fields were enough while the walk was strictly sequential; once
+// WithParallelism lets slice/map elements be visited concurrently, both need
+// a mutex.
+type walkState struct {
+	mu         sync.Mutex
+	visited    map[visitKey]*FieldMeta
+	visitCount int
+}
+
+func newWalkState() *walkState {
+	return &walkState{visited: make(map[visitKey]*FieldMeta)}
+}
+
+// admit checks the visit against maxVisits and, if trackIdentity is set,
+// against the cycle-detection map, atomically with reserving a visit slot.
+// It returns the previously-emitted meta (and isCycle=true) if key has been
+// seen before, or allowed=false if the walk has hit maxVisits.
+func (s *walkState) admit(key visitKey, trackIdentity bool, maxVisits int) (prev *FieldMeta, isCycle bool, allowed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if trackIdentity {
+		if p, seen := s.visited[key]; seen {
+			return p, true, true
+		}
+	}
+
+	if maxVisits > 0 && s.visitCount >= maxVisits {
+		return nil, false, false
+	}
+	s.visitCount++
+	return nil, false, true
+}
+
+// record stores meta as the emitted node for key, so a later cycle back to
+// the same identity can link to it via CycleRef.
+func (s *walkState) record(key visitKey, trackIdentity bool, meta *FieldMeta) {
+	if !trackIdentity {
+		return
+	}
+	s.mu.Lock()
+	s.visited[key] = meta
+	s.mu.Unlock()
+}
+
+// visitKey identifies a pointer-like reflect.Value (pointer, interface, map,
+// slice or channel) by its underlying type and address, so the walk can
+// detect cycles even when two distinct pointers reference equal-valued data,
+// and can tell apart a *Foo and a *Bar that happen to share an address.
+type visitKey struct {
+	t   reflect.Type
+	ptr unsafe.Pointer
+}
+
+// pointerKey returns the visitKey for v and whether v's identity should be
+// tracked for cycle detection. Only pointer-like kinds carry a stable
+// address; everything else (structs, numbers, strings, ...) returns ok=false.
+func pointerKey(v reflect.Value) (visitKey, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return visitKey{}, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan:
+		if v.IsNil() {
+			return visitKey{}, false
+		}
+		return visitKey{t: v.Type(), ptr: unsafe.Pointer(v.Pointer())}, true
+	default:
+		return visitKey{}, false
+	}
 }
 
 func defaultSettings() *walkSettings {
@@ -40,6 +133,7 @@ func defaultSettings() *walkSettings {
 		tagFilter:      nil,
 		typeFilter:     nil,
 		metaFilter:     nil,
+		pathEncoder:    DotPath{},
 	}
 }
 
@@ -49,17 +143,14 @@ func Walk(obj interface{}, options ...Option) (*FieldMeta, map[string]*FieldMeta
 		option(settings)
 	}
 
-	visited := make(map[uintptr]bool)
+	state := newWalkState()
 
 	t := reflect.TypeOf(obj)
-	rootPath := t.Name()
-	if rootPath == "" {
-		rootPath = t.String()
-	}
+	rootPath := settings.pathEncoder.Root(t)
 
 	flatMap := sync.Map{}
 
-	fieldMap, err := walkRecursive(settings, obj, 0, visited, rootPath, &flatMap)
+	fieldMap, err := walkRecursive(settings, obj, 0, state, rootPath, reflect.StructTag(""), nil, &flatMap)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,8 +169,10 @@ func walkRecursive(
 	settings *walkSettings,
 	obj interface{},
 	depth int,
-	visited map[uintptr]bool,
+	state *walkState,
 	path string,
+	tag reflect.StructTag,
+	parent *FieldMeta,
 	flatMap *sync.Map,
 ) (*FieldMeta, error) {
 
@@ -95,30 +188,42 @@ func walkRecursive(
 
 	t := v.Type()
 
-	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
-		addr := v.Pointer()
-		if addr != 0 {
-			if visited[addr] {
-				return nil, nil
-			}
-			visited[addr] = true
-		}
-	}
-
 	name := t.Name()
 	if name == "" {
-
 		name = t.String()
 	}
 
+	key, trackIdentity := pointerKey(v)
+	prev, isCycle, allowed := state.admit(key, trackIdentity, settings.maxVisits)
+	if !allowed {
+		return nil, nil
+	}
+	if isCycle {
+		cycleMeta := &FieldMeta{
+			Name:      name,
+			CanSet:    v.CanSet(),
+			Path:      path,
+			Type:      t,
+			IsPrivate: t.PkgPath() != "",
+			Tag:       tag,
+			Parent:    parent,
+			CycleRef:  prev,
+		}
+		flatMap.Store(path, cycleMeta)
+		return cycleMeta, nil
+	}
+
 	meta := &FieldMeta{
 		Name:      name,
 		CanSet:    v.CanSet(),
 		Path:      path,
 		Type:      t,
 		IsPrivate: t.PkgPath() != "",
+		Tag:       tag,
+		Parent:    parent,
 	}
 	flatMap.Store(path, meta)
+	state.record(key, trackIdentity, meta)
 
 	for _, fn := range settings.userFuncs {
 		if err := fn(v, *meta); err != nil {
@@ -130,70 +235,95 @@ func walkRecursive(
 	case reflect.Ptr, reflect.Interface:
 
 		if !v.IsNil() {
-			childPath := path + ".*"
-			childMeta, err := walkRecursive(settings, v.Elem().Interface(), depth+1, visited, childPath, flatMap)
+			childPath := settings.pathEncoder.Deref(path)
+			childMeta, err := walkRecursive(settings, v.Elem().Interface(), depth+1, state, childPath, reflect.StructTag(""), meta, flatMap)
 			if err != nil {
 				return nil, err
 			}
 			if childMeta != nil {
 				meta.Children = append(meta.Children, childMeta)
-				childMeta.Parent = meta
 			}
 		}
 
 	case reflect.Struct:
-		for i := 0; i < v.NumField(); i++ {
-			fieldVal := v.Field(i)
-			fieldType := t.Field(i)
+		plan := getTypePlan(t)
+		for _, fp := range plan.fields {
+			if settings.tagFilter != nil && !settings.tagFilter(fp.tag) {
+				continue
+			}
 
-			if settings.tagFilter != nil && !settings.tagFilter(fieldType.Tag) {
+			if settings.typeFilter != nil && !settings.typeFilter(fp.field.Type) {
 				continue
 			}
 
-			if !settings.includePrivate && fieldType.PkgPath != "" {
+			if !settings.includePrivate && fp.field.PkgPath != "" {
 				continue
 			}
 
+			fieldVal := v.Field(fp.index)
+
 			if settings.onlySettable && !fieldVal.CanSet() {
 				continue
 			}
 
-			newPath := path + "." + fieldType.Name
-			childMeta, err := walkRecursive(settings, fieldVal.Interface(), depth+1, visited, newPath, flatMap)
+			newPath := settings.pathEncoder.Field(path, fp.field)
+			childMeta, err := walkRecursive(settings, fieldVal.Interface(), depth+1, state, newPath, fp.tag, meta, flatMap)
 			if err != nil {
 				return nil, err
 			}
 			if childMeta != nil {
 				meta.Children = append(meta.Children, childMeta)
-				childMeta.Parent = meta
 			}
 		}
 
 	case reflect.Slice, reflect.Array:
-		for i := 0; i < v.Len(); i++ {
-			elem := v.Index(i)
-			newPath := fmt.Sprintf("%s[%d]", path, i)
-			childMeta, err := walkRecursive(settings, elem.Interface(), depth+1, visited, newPath, flatMap)
+		n := v.Len()
+		if settings.parallelism > 1 && n >= settings.parallelThreshold {
+			err := walkIndexedParallel(settings, n, func(i int) (*FieldMeta, error) {
+				elem := v.Index(i)
+				newPath := settings.pathEncoder.Index(path, i)
+				return walkRecursive(settings, elem.Interface(), depth+1, state, newPath, reflect.StructTag(""), meta, flatMap)
+			}, meta)
 			if err != nil {
 				return nil, err
 			}
-			if childMeta != nil {
-				meta.Children = append(meta.Children, childMeta)
-				childMeta.Parent = meta
+		} else {
+			for i := 0; i < n; i++ {
+				elem := v.Index(i)
+				newPath := settings.pathEncoder.Index(path, i)
+				childMeta, err := walkRecursive(settings, elem.Interface(), depth+1, state, newPath, reflect.StructTag(""), meta, flatMap)
+				if err != nil {
+					return nil, err
+				}
+				if childMeta != nil {
+					meta.Children = append(meta.Children, childMeta)
+				}
 			}
 		}
 
 	case reflect.Map:
-		for _, key := range v.MapKeys() {
-			value := v.MapIndex(key)
-			newPath := fmt.Sprintf("%s[%v]", path, key.Interface())
-			childMeta, err := walkRecursive(settings, value.Interface(), depth+1, visited, newPath, flatMap)
+		keys := v.MapKeys()
+		if settings.parallelism > 1 && len(keys) >= settings.parallelThreshold {
+			err := walkIndexedParallel(settings, len(keys), func(i int) (*FieldMeta, error) {
+				mapKey := keys[i]
+				value := v.MapIndex(mapKey)
+				newPath := settings.pathEncoder.MapKey(path, mapKey)
+				return walkRecursive(settings, value.Interface(), depth+1, state, newPath, reflect.StructTag(""), meta, flatMap)
+			}, meta)
 			if err != nil {
 				return nil, err
 			}
-			if childMeta != nil {
-				meta.Children = append(meta.Children, childMeta)
-				childMeta.Parent = meta
+		} else {
+			for _, mapKey := range keys {
+				value := v.MapIndex(mapKey)
+				newPath := settings.pathEncoder.MapKey(path, mapKey)
+				childMeta, err := walkRecursive(settings, value.Interface(), depth+1, state, newPath, reflect.StructTag(""), meta, flatMap)
+				if err != nil {
+					return nil, err
+				}
+				if childMeta != nil {
+					meta.Children = append(meta.Children, childMeta)
+				}
 			}
 		}
 	}
@@ -344,3 +474,14 @@ func WithUserFunc(fn UserFunc) Option {
 		s.userFuncs = append(s.userFuncs, fn)
 	}
 }
+
+// WithMaxVisits caps the total number of nodes walkRecursive will visit, as
+// a second safety net alongside cycle detection and MaxDepth. Once the cap is
+// reached, remaining nodes are skipped silently. A node that closes a cycle
+// does not count toward the cap and still gets a FieldMeta with CycleRef set.
+// n <= 0 means unlimited (the default).
+func WithMaxVisits(n int) Option {
+	return func(s *walkSettings) {
+		s.maxVisits = n
+	}
+}
@@ -17,16 +17,109 @@ type BenchStruct struct {
 	H *int
 }
 
-var userFunc = func(v reflect.Value, meta ObjMeta) error {
+var userFunc = func(v reflect.Value, meta FieldMeta) error {
 	return nil
 }
 
+// deeplyNested is a struct chain several levels deep, used alongside
+// BenchStruct to show the type-plan cache paying off even more as nesting
+// grows (more reflect.Type.Field calls avoided per walk).
+type deeplyNestedLevel5 struct {
+	A int
+	B string
+}
+
+type deeplyNestedLevel4 struct {
+	Next deeplyNestedLevel5
+	A    int
+}
+
+type deeplyNestedLevel3 struct {
+	Next deeplyNestedLevel4
+	A    int
+}
+
+type deeplyNestedLevel2 struct {
+	Next deeplyNestedLevel3
+	A    int
+}
+
+type DeeplyNested struct {
+	Next deeplyNestedLevel2
+	A    int
+}
+
 func BenchmarkWalk(b *testing.B) {
 	benchStruct := &BenchStruct{}
 
 	b.Run("default", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			_, _ = Walk(benchStruct, userFunc)
+			_, _, _ = Walk(benchStruct, WithUserFunc(userFunc))
+		}
+	})
+}
+
+// BenchmarkWalkTypePlan compares walking with a cold type-plan cache (every
+// iteration re-analyzes every reflect.Type from scratch, the pre-cache
+// behavior) against a warm cache (the steady-state behavior once a type has
+// been seen once), on both BenchStruct and a deeply nested type.
+func BenchmarkWalkTypePlan(b *testing.B) {
+	benchStruct := &BenchStruct{}
+	deepStruct := &DeeplyNested{}
+
+	b.Run("BenchStruct/cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ResetCache()
+			_, _, _ = Walk(benchStruct, WithUserFunc(userFunc))
+		}
+	})
+
+	b.Run("BenchStruct/warm", func(b *testing.B) {
+		ResetCache()
+		_, _, _ = Walk(benchStruct, WithUserFunc(userFunc))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _, _ = Walk(benchStruct, WithUserFunc(userFunc))
+		}
+	})
+
+	b.Run("DeeplyNested/cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ResetCache()
+			_, _, _ = Walk(deepStruct, WithUserFunc(userFunc))
+		}
+	})
+
+	b.Run("DeeplyNested/warm", func(b *testing.B) {
+		ResetCache()
+		_, _, _ = Walk(deepStruct, WithUserFunc(userFunc))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _, _ = Walk(deepStruct, WithUserFunc(userFunc))
+		}
+	})
+}
+
+// BenchmarkWalkLargeSlice walks a []BenchStruct of length 100k sequentially
+// versus with a worker pool, to show WithParallelism scaling on large
+// collections.
+func BenchmarkWalkLargeSlice(b *testing.B) {
+	const size = 100_000
+	items := make([]BenchStruct, size)
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = Walk(&items, WithUserFunc(userFunc))
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = Walk(&items,
+				WithUserFunc(userFunc),
+				WithParallelism(8),
+				WithParallelThreshold(1000),
+			)
 		}
 	})
 }
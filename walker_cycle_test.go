@@ -0,0 +1,93 @@
+package gowalker
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+func TestWalkSelfReferencingNodeTerminates(t *testing.T) {
+	n := &Node{Value: 1}
+	n.Next = n
+
+	root, flat, err := Walk(n, WithUserFunc(func(v reflect.Value, meta FieldMeta) error {
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if root.CycleRef != nil {
+		t.Fatalf("root should not be its own cycle ref")
+	}
+
+	nextMeta, ok := flat[root.Path+".*.Next"]
+	if !ok {
+		t.Fatalf("expected flat map to contain %q", root.Path+".*.Next")
+	}
+
+	if nextMeta.CycleRef == nil {
+		t.Fatalf("expected Next to be flagged as a cycle back to the root")
+	}
+	if nextMeta.CycleRef.Path != root.Path {
+		t.Fatalf("CycleRef.Path = %q, want %q", nextMeta.CycleRef.Path, root.Path)
+	}
+	if len(nextMeta.Children) != 0 {
+		t.Fatalf("cycle node should not have been descended into, got %d children", len(nextMeta.Children))
+	}
+}
+
+type MutualA struct {
+	B *MutualB
+}
+
+type MutualB struct {
+	A *MutualA
+}
+
+func TestWalkMutuallyRecursiveTypesTerminates(t *testing.T) {
+	a := &MutualA{}
+	b := &MutualB{}
+	a.B = b
+	b.A = a
+
+	root, flat, err := Walk(a, WithUserFunc(func(v reflect.Value, meta FieldMeta) error {
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	backToA, ok := flat[root.Path+".*.B.*.A"]
+	if !ok {
+		t.Fatalf("expected flat map to contain %q", root.Path+".*.B.*.A")
+	}
+	if backToA.CycleRef == nil {
+		t.Fatalf("expected A to be flagged as a cycle back to the root")
+	}
+	if backToA.CycleRef.Path != root.Path {
+		t.Fatalf("CycleRef.Path = %q, want %q", backToA.CycleRef.Path, root.Path)
+	}
+}
+
+func TestWithMaxVisitsCapsWalk(t *testing.T) {
+	type Chain struct {
+		A, B, C, D int
+	}
+
+	var visits int
+	_, _, err := Walk(&Chain{}, WithMaxVisits(2), WithUserFunc(func(v reflect.Value, meta FieldMeta) error {
+		visits++
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	if visits > 2 {
+		t.Fatalf("WithMaxVisits(2) allowed %d visits", visits)
+	}
+}
@@ -0,0 +1,123 @@
+package gowalker
+
+import "sync"
+
+// WithParallelism bounds walkRecursive to at most n concurrently-running
+// goroutines when traversing the elements of a slice, array or map whose
+// length meets WithParallelThreshold. n <= 1 (the default) keeps the walk
+// sequential. UserFuncs registered with WithUserFunc must be safe to call
+// concurrently when this option is used: the walk's own flatMap already
+// tolerates concurrent access (it's a sync.Map), but any shared state a
+// UserFunc closes over needs its own synchronization.
+func WithParallelism(n int) Option {
+	return func(s *walkSettings) {
+		s.parallelism = n
+	}
+}
+
+// WithParallelThreshold sets the minimum slice/array/map length at which
+// WithParallelism kicks in; shorter collections are always walked
+// sequentially, since spinning up goroutines for a handful of elements costs
+// more than it saves.
+func WithParallelThreshold(minLen int) Option {
+	return func(s *walkSettings) {
+		s.parallelThreshold = minLen
+	}
+}
+
+// WithOrderedChildren controls how a parallel-walked collection's results
+// are assembled onto the parent FieldMeta's Children. When true, results are
+// gathered into a slice preallocated by element index so Children comes out
+// in source order despite concurrent execution; when false (the default),
+// children are appended in whatever order their goroutines finish, which is
+// cheaper but non-deterministic.
+func WithOrderedChildren(ordered bool) Option {
+	return func(s *walkSettings) {
+		s.orderedChildren = ordered
+	}
+}
+
+type indexedResult struct {
+	index int
+	meta  *FieldMeta
+	err   error
+}
+
+// walkIndexedParallel runs walkOne for each index in [0, n) across a worker
+// pool of settings.parallelism goroutines (each worker pulls indices off a
+// shared channel rather than one goroutine being spawned per index, so the
+// pool stays bounded even for very large n), then attaches the resulting
+// FieldMetas (skipping nils) onto parent.Children. Ordering is controlled by
+// settings.orderedChildren. An error does not stop the other workers: every
+// index is still walked, and the first error received is returned once all
+// workers have finished.
+func walkIndexedParallel(settings *walkSettings, n int, walkOne func(i int) (*FieldMeta, error), parent *FieldMeta) error {
+	workers := settings.parallelism
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indices := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	results := make(chan indexedResult, n)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				meta, err := walkOne(i)
+				results <- indexedResult{index: i, meta: meta, err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var ordered []*FieldMeta
+	if settings.orderedChildren {
+		ordered = make([]*FieldMeta, n)
+	}
+
+	var firstErr error
+	for r := range results {
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+		if r.meta == nil {
+			continue
+		}
+		if settings.orderedChildren {
+			ordered[r.index] = r.meta
+			continue
+		}
+		parent.Children = append(parent.Children, r.meta)
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if settings.orderedChildren {
+		for _, childMeta := range ordered {
+			if childMeta == nil {
+				continue
+			}
+			parent.Children = append(parent.Children, childMeta)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package gowalker
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWalkParallelVisitsAllElements(t *testing.T) {
+	items := make([]int, 500)
+	for i := range items {
+		items[i] = i
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+
+	_, _, err := Walk(&items,
+		WithParallelism(8),
+		WithParallelThreshold(10),
+		WithUserFunc(func(v reflect.Value, meta FieldMeta) error {
+			if v.Kind() != reflect.Int {
+				return nil
+			}
+			mu.Lock()
+			seen[int(v.Int())] = true
+			mu.Unlock()
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if len(seen) != len(items) {
+		t.Fatalf("expected to visit %d elements, saw %d", len(items), len(seen))
+	}
+}
+
+func TestWalkParallelBelowThresholdStaysSequential(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	_, flat, err := Walk(&items,
+		WithParallelism(8),
+		WithParallelThreshold(1000),
+		WithUserFunc(func(v reflect.Value, meta FieldMeta) error { return nil }),
+	)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if len(flat) != 5 { // root, *, [0], [1], [2]
+		t.Fatalf("expected 5 flat entries, got %d", len(flat))
+	}
+}
+
+func TestWithOrderedChildrenPreservesSourceOrder(t *testing.T) {
+	items := make([]int, 200)
+	for i := range items {
+		items[i] = i
+	}
+
+	root, _, err := Walk(&items,
+		WithParallelism(8),
+		WithParallelThreshold(10),
+		WithOrderedChildren(true),
+		WithUserFunc(func(v reflect.Value, meta FieldMeta) error { return nil }),
+	)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	sliceMeta := root.Children[0]
+	if len(sliceMeta.Children) != len(items) {
+		t.Fatalf("expected %d children, got %d", len(items), len(sliceMeta.Children))
+	}
+	for i, child := range sliceMeta.Children {
+		want := fmt.Sprintf("[%d]", i)
+		if !strings.HasSuffix(child.Path, want) {
+			t.Fatalf("child %d has unexpected path %q, want suffix %q", i, child.Path, want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package gowalker
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PathEncoder controls how walkRecursive renders a node's location as a
+// string Path. Implementations only need to be consistent with themselves:
+// Find resolves a selector back to a FieldMeta by looking it up verbatim in
+// the flat map, whichever encoding produced it.
+type PathEncoder interface {
+	// Root returns the path for the object passed to Walk itself.
+	Root(t reflect.Type) string
+	// Field returns the path for a struct field reached from parent.
+	Field(parent string, sf reflect.StructField) string
+	// Index returns the path for a slice/array element reached from parent.
+	Index(parent string, i int) string
+	// MapKey returns the path for a map value reached from parent.
+	MapKey(parent string, k reflect.Value) string
+	// Deref returns the path for the value pointed to by a pointer or held
+	// by an interface reached from parent.
+	Deref(parent string) string
+}
+
+// DotPath is the original gowalker path encoding: dotted field names,
+// bracketed slice/map indices, and ".*" for pointer/interface dereferences,
+// e.g. "Config.Servers[0].Tags[env]".
+type DotPath struct{}
+
+func (DotPath) Root(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		name = t.String()
+	}
+	return name
+}
+
+func (DotPath) Field(parent string, sf reflect.StructField) string {
+	return parent + "." + sf.Name
+}
+
+func (DotPath) Index(parent string, i int) string {
+	return fmt.Sprintf("%s[%d]", parent, i)
+}
+
+func (DotPath) MapKey(parent string, k reflect.Value) string {
+	return fmt.Sprintf("%s[%v]", parent, k.Interface())
+}
+
+func (DotPath) Deref(parent string) string {
+	return parent + ".*"
+}
+
+// JSONPointer renders paths as RFC 6901 JSON Pointers built from Go field
+// names, e.g. "/Servers/0/Tags/env".
+// The root is the empty string, per the spec's definition of the whole
+// document. Pointers are transparent, as they are in encoding/json: a
+// pointer and the value it refers to share the same path.
+type JSONPointer struct{}
+
+func (JSONPointer) Root(t reflect.Type) string {
+	return ""
+}
+
+func (JSONPointer) Field(parent string, sf reflect.StructField) string {
+	return parent + "/" + jsonPointerEscape(sf.Name)
+}
+
+func (JSONPointer) Index(parent string, i int) string {
+	return parent + "/" + strconv.Itoa(i)
+}
+
+func (JSONPointer) MapKey(parent string, k reflect.Value) string {
+	return parent + "/" + jsonPointerEscape(fmt.Sprintf("%v", k.Interface()))
+}
+
+func (JSONPointer) Deref(parent string) string {
+	return parent
+}
+
+func jsonPointerEscape(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	s = strings.ReplaceAll(s, "/", "~1")
+	return s
+}
+
+// JSONTagPath is DotPath, except a struct field's path segment uses its
+// `json:"..."` tag name when present (honoring "-" to mean "no name", which
+// falls back to the Go field name, and ignoring options like ",omitempty").
+// Unlike encoding/json, "-" does not exclude the field from the walk itself -
+// a PathEncoder only renders the path of a field the walk already visits;
+// use WithTagFilter(IgnoreTag("json", "-")) to skip such fields entirely.
+type JSONTagPath struct{}
+
+func (JSONTagPath) Root(t reflect.Type) string {
+	return DotPath{}.Root(t)
+}
+
+func (JSONTagPath) Field(parent string, sf reflect.StructField) string {
+	name := sf.Name
+	if tag, ok := sf.Tag.Lookup("json"); ok {
+		tagName := strings.Split(tag, ",")[0]
+		if tagName != "" && tagName != "-" {
+			name = tagName
+		}
+	}
+	return parent + "." + name
+}
+
+func (JSONTagPath) Index(parent string, i int) string {
+	return DotPath{}.Index(parent, i)
+}
+
+func (JSONTagPath) MapKey(parent string, k reflect.Value) string {
+	return DotPath{}.MapKey(parent, k)
+}
+
+func (JSONTagPath) Deref(parent string) string {
+	return DotPath{}.Deref(parent)
+}
+
+// WithPathEncoder sets the PathEncoder Walk and WalkVisit use to render each
+// node's Path. The default is DotPath, gowalker's original encoding.
+func WithPathEncoder(enc PathEncoder) Option {
+	return func(s *walkSettings) {
+		s.pathEncoder = enc
+	}
+}
+
+// Find resolves selector, a path string produced by whichever PathEncoder
+// the walk used, to the FieldMeta(s) at that location in flat. It is a
+// direct lookup, so selector must match a Path exactly as rendered by that
+// encoder (e.g. a JSON Pointer if WithPathEncoder(JSONPointer{}) was used).
+func Find(root *FieldMeta, flat map[string]*FieldMeta, selector string) ([]*FieldMeta, error) {
+	if meta, ok := flat[selector]; ok {
+		return []*FieldMeta{meta}, nil
+	}
+	return nil, fmt.Errorf("gowalker: no node found for selector %q", selector)
+}
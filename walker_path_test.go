@@ -0,0 +1,179 @@
+package gowalker
+
+import (
+	"reflect"
+	"testing"
+)
+
+type pathInner struct {
+	Token string `json:"token"`
+}
+
+type pathOuter struct {
+	Name   string            `json:"name"`
+	Tags   []string          `json:"tags"`
+	Lookup map[string]string `json:"lookup"`
+	Inner  *pathInner        `json:"inner"`
+}
+
+func newPathOuter() pathOuter {
+	return pathOuter{
+		Name:   "alice",
+		Tags:   []string{"a", "b"},
+		Lookup: map[string]string{"k": "v"},
+		Inner:  &pathInner{Token: "t"},
+	}
+}
+
+func TestDotPathIsDefaultEncoding(t *testing.T) {
+	obj := newPathOuter()
+
+	_, flat, err := Walk(obj)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"pathOuter.Name",
+		"pathOuter.Tags",
+		"pathOuter.Tags[0]",
+		"pathOuter.Lookup[k]",
+		"pathOuter.Inner",
+		"pathOuter.Inner.*",
+		"pathOuter.Inner.*.Token",
+	} {
+		if _, ok := flat[want]; !ok {
+			t.Errorf("missing flat entry %q", want)
+		}
+	}
+}
+
+func TestJSONPointerEncoding(t *testing.T) {
+	obj := newPathOuter()
+
+	_, flat, err := Walk(obj, WithPathEncoder(JSONPointer{}))
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"",
+		"/Name",
+		"/Tags",
+		"/Tags/0",
+		"/Lookup/k",
+		"/Inner",
+		"/Inner/Token",
+	} {
+		if _, ok := flat[want]; !ok {
+			t.Errorf("missing flat entry %q", want)
+		}
+	}
+}
+
+func TestJSONPointerEscapesTildeAndSlash(t *testing.T) {
+	m := map[string]string{"a/b~c": "v"}
+
+	_, flat, err := Walk(&m, WithPathEncoder(JSONPointer{}))
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if _, ok := flat["/a~1b~0c"]; !ok {
+		t.Errorf("expected escaped map key path in %v", keysOf(flat))
+	}
+}
+
+func TestJSONTagPathUsesTagName(t *testing.T) {
+	obj := newPathOuter()
+
+	_, flat, err := Walk(obj, WithPathEncoder(JSONTagPath{}))
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if _, ok := flat["pathOuter.name"]; !ok {
+		t.Errorf("expected tag-named path pathOuter.name in %v", keysOf(flat))
+	}
+	if _, ok := flat["pathOuter.Name"]; ok {
+		t.Errorf("did not expect Go field name path pathOuter.Name in %v", keysOf(flat))
+	}
+}
+
+func TestJSONTagPathFallsBackToFieldNameForDashTag(t *testing.T) {
+	type withHidden struct {
+		Hidden string `json:"-"`
+	}
+
+	_, flat, err := Walk(withHidden{Hidden: "v"}, WithPathEncoder(JSONTagPath{}))
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if _, ok := flat["withHidden.Hidden"]; !ok {
+		t.Errorf("expected json:\"-\" to fall back to the Go field name in %v", keysOf(flat))
+	}
+	if _, ok := flat["withHidden.-"]; ok {
+		t.Errorf("did not expect a literal \"-\" path segment in %v", keysOf(flat))
+	}
+}
+
+func TestWalkVisitHonorsPathEncoder(t *testing.T) {
+	obj := newPathOuter()
+
+	var sawToken bool
+	visitor := Visitor{
+		Enter: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			if meta.Path == "/Inner/Token" {
+				sawToken = true
+			}
+			return ActionContinue, nil
+		},
+	}
+
+	_, _, err := WalkVisit(obj, visitor, WithPathEncoder(JSONPointer{}))
+	if err != nil {
+		t.Fatalf("WalkVisit() error = %v", err)
+	}
+	if !sawToken {
+		t.Error("expected WalkVisit to render paths with the configured PathEncoder")
+	}
+}
+
+func TestFindReturnsNodeForSelector(t *testing.T) {
+	obj := newPathOuter()
+
+	root, flat, err := Walk(obj)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	found, err := Find(root, flat, "pathOuter.Name")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Path != "pathOuter.Name" {
+		t.Errorf("Find() = %v, want single node at pathOuter.Name", found)
+	}
+}
+
+func TestFindReturnsErrorForUnknownSelector(t *testing.T) {
+	obj := newPathOuter()
+
+	root, flat, err := Walk(obj)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if _, err := Find(root, flat, "pathOuter.DoesNotExist"); err == nil {
+		t.Error("Find() error = nil, want error for unknown selector")
+	}
+}
+
+func keysOf(m map[string]*FieldMeta) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -62,7 +62,15 @@ type TestCase struct {
 func TestVisitedFieldsWithDifferentOptions(t *testing.T) {
 	testCases := []TestCase{
 		{
-			Name: "TestMaxDepth",
+			// WithTypeFilter is checked against a struct field's own type
+			// while deciding whether to descend into it, the same way
+			// WithTagFilter is - not against every node the walk ever
+			// constructs. S1's type isn't int, so the filter prunes S1 (and
+			// everything under it, including the int field SF1) without
+			// ever looking inside it; the root pointer and the struct it
+			// derefs to are still visited, since the filter only gates
+			// struct *field* descent.
+			Name: "TestTypeFilterPrunesNonMatchingFieldSubtree",
 			Obj: &struct {
 				S1 struct {
 					S2 struct {
@@ -77,7 +85,10 @@ func TestVisitedFieldsWithDifferentOptions(t *testing.T) {
 				}),
 				WithTypeFilter(TypeIsOneOf(reflect.TypeOf(0))),
 			},
-			ExpectedPaths: map[string]bool{},
+			ExpectedPaths: map[string]bool{
+				"*struct { S1 struct { S2 struct { SF1 int } } }":   true,
+				"*struct { S1 struct { S2 struct { SF1 int } } }.*": true,
+			},
 		},
 		// {
 		// 	Name: "TestPrivateFields",
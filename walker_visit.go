@@ -0,0 +1,339 @@
+package gowalker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// actionKind distinguishes the handful of instructions a VisitFunc can give
+// the engine. Action values are produced only through the exported
+// constants/constructors below.
+type actionKind int
+
+const (
+	actionKindContinue actionKind = iota
+	actionKindSkip
+	actionKindBreak
+	actionKindReplace
+)
+
+// Action tells WalkVisit what to do after a VisitFunc runs for a node.
+type Action struct {
+	kind     actionKind
+	newValue reflect.Value
+}
+
+var (
+	// ActionContinue descends into the node's children as usual.
+	ActionContinue = Action{kind: actionKindContinue}
+	// ActionSkip leaves the node's children unvisited.
+	ActionSkip = Action{kind: actionKindSkip}
+	// ActionBreak stops the entire walk immediately, without an error.
+	ActionBreak = Action{kind: actionKindBreak}
+)
+
+// ActionReplace writes newValue into the current node's location (via
+// reflection) and does not descend into its (now stale) children. It is an
+// error if the node is not settable.
+func ActionReplace(newValue reflect.Value) Action {
+	return Action{kind: actionKindReplace, newValue: newValue}
+}
+
+// VisitFunc is called on Enter (before a node's children are visited) and on
+// Leave (after), and returns the Action the engine should take.
+type VisitFunc func(v reflect.Value, meta FieldMeta) (Action, error)
+
+// Visitor pairs an Enter and a Leave callback for WalkVisit. Either may be
+// nil, in which case ActionContinue is assumed for that stage.
+type Visitor struct {
+	Enter VisitFunc
+	Leave VisitFunc
+}
+
+// errWalkBroken is an internal sentinel used to unwind the recursion when a
+// VisitFunc returns ActionBreak; WalkVisit translates it back into a nil
+// error.
+var errWalkBroken = errors.New("gowalker: walk broken")
+
+// fieldSetter writes a replacement value back into the slot a node was read
+// from (a struct field, slice/array element, map entry or pointer target).
+// It is nil for nodes with no settable location (e.g. the root value itself,
+// when not passed as a pointer).
+type fieldSetter func(reflect.Value) error
+
+// checkAssignable reports an error, rather than letting reflect.Value.Set or
+// SetMapIndex panic, when an ActionReplace value's type doesn't match the
+// location it's being written into.
+func checkAssignable(nv reflect.Value, target reflect.Type) error {
+	if !nv.IsValid() {
+		return fmt.Errorf("replacement value is invalid")
+	}
+	if !nv.Type().AssignableTo(target) {
+		return fmt.Errorf("value of type %s is not assignable to %s", nv.Type(), target)
+	}
+	return nil
+}
+
+// WalkVisit walks obj like Walk, but drives a Visitor instead of a flat list
+// of UserFuncs. Enter fires before a node's children are visited and Leave
+// fires after, in reverse order of Enter (innermost first), mirroring
+// graphql-go's visitor. ActionReplace writes its value back into the node's
+// location using the cached field index from the type plan; unlike Walk, it
+// recurses on reflect.Values rather than boxing each value through
+// interface{}, so addressable fields stay settable.
+func WalkVisit(obj interface{}, visitor Visitor, options ...Option) (*FieldMeta, map[string]*FieldMeta, error) {
+	settings := defaultSettings()
+	for _, option := range options {
+		option(settings)
+	}
+
+	v := reflect.ValueOf(obj)
+	if !v.IsValid() {
+		return nil, nil, nil
+	}
+
+	t := v.Type()
+	rootPath := settings.pathEncoder.Root(t)
+
+	visited := make(map[visitKey]*FieldMeta)
+	visitCount := 0
+	flatMap := sync.Map{}
+
+	root, err := walkVisitRecursive(settings, visitor, v, nil, 0, visited, &visitCount, rootPath, reflect.StructTag(""), &flatMap)
+	if err != nil && !errors.Is(err, errWalkBroken) {
+		return nil, nil, err
+	}
+
+	m := make(map[string]*FieldMeta)
+	flatMap.Range(func(key, value interface{}) bool {
+		m[key.(string)] = value.(*FieldMeta)
+		return true
+	})
+
+	return root, m, nil
+}
+
+func walkVisitRecursive(
+	settings *walkSettings,
+	visitor Visitor,
+	v reflect.Value,
+	setter fieldSetter,
+	depth int,
+	visited map[visitKey]*FieldMeta,
+	visitCount *int,
+	path string,
+	tag reflect.StructTag,
+	flatMap *sync.Map,
+) (*FieldMeta, error) {
+
+	if depth > settings.maxDepth {
+		return nil, nil
+	}
+
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, nil
+	}
+
+	if settings.maxVisits > 0 && *visitCount >= settings.maxVisits {
+		return nil, nil
+	}
+
+	t := v.Type()
+
+	name := t.Name()
+	if name == "" {
+		name = t.String()
+	}
+
+	key, trackIdentity := pointerKey(v)
+	if trackIdentity {
+		if prev, seen := visited[key]; seen {
+			cycleMeta := &FieldMeta{
+				Name:      name,
+				CanSet:    v.CanSet(),
+				Path:      path,
+				Type:      t,
+				IsPrivate: t.PkgPath() != "",
+				Tag:       tag,
+				CycleRef:  prev,
+			}
+			flatMap.Store(path, cycleMeta)
+			return cycleMeta, nil
+		}
+	}
+
+	*visitCount++
+
+	meta := &FieldMeta{
+		Name:      name,
+		CanSet:    v.CanSet(),
+		Path:      path,
+		Type:      t,
+		IsPrivate: t.PkgPath() != "",
+		Tag:       tag,
+	}
+	flatMap.Store(path, meta)
+
+	if trackIdentity {
+		visited[key] = meta
+	}
+
+	descend := true
+
+	if visitor.Enter != nil {
+		action, err := visitor.Enter(v, *meta)
+		if err != nil {
+			return meta, err
+		}
+		switch action.kind {
+		case actionKindBreak:
+			return meta, errWalkBroken
+		case actionKindSkip:
+			descend = false
+		case actionKindReplace:
+			if setter == nil {
+				return meta, fmt.Errorf("gowalker: cannot replace %s: no settable location", path)
+			}
+			if err := setter(action.newValue); err != nil {
+				return meta, fmt.Errorf("gowalker: replace %s: %w", path, err)
+			}
+			descend = false
+		}
+	}
+
+	if descend {
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if !v.IsNil() {
+				elem := v.Elem()
+				elemSetter := func(nv reflect.Value) error {
+					if !elem.CanSet() {
+						return fmt.Errorf("field is not settable")
+					}
+					if err := checkAssignable(nv, elem.Type()); err != nil {
+						return err
+					}
+					elem.Set(nv)
+					return nil
+				}
+				childMeta, err := walkVisitRecursive(settings, visitor, elem, elemSetter, depth+1, visited, visitCount, settings.pathEncoder.Deref(path), reflect.StructTag(""), flatMap)
+				if err != nil {
+					return meta, err
+				}
+				if childMeta != nil {
+					meta.Children = append(meta.Children, childMeta)
+					childMeta.Parent = meta
+				}
+			}
+
+		case reflect.Struct:
+			plan := getTypePlan(t)
+			for _, fp := range plan.fields {
+				if settings.tagFilter != nil && !settings.tagFilter(fp.tag) {
+					continue
+				}
+				if settings.typeFilter != nil && !settings.typeFilter(fp.field.Type) {
+					continue
+				}
+				if !settings.includePrivate && fp.field.PkgPath != "" {
+					continue
+				}
+
+				fieldVal := v.Field(fp.index)
+				if settings.onlySettable && !fieldVal.CanSet() {
+					continue
+				}
+
+				fieldSet := func(nv reflect.Value) error {
+					if !fieldVal.CanSet() {
+						return fmt.Errorf("field is not settable")
+					}
+					if err := checkAssignable(nv, fieldVal.Type()); err != nil {
+						return err
+					}
+					fieldVal.Set(nv)
+					return nil
+				}
+
+				newPath := settings.pathEncoder.Field(path, fp.field)
+				childMeta, err := walkVisitRecursive(settings, visitor, fieldVal, fieldSet, depth+1, visited, visitCount, newPath, fp.tag, flatMap)
+				if err != nil {
+					return meta, err
+				}
+				if childMeta != nil {
+					meta.Children = append(meta.Children, childMeta)
+					childMeta.Parent = meta
+				}
+			}
+
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				elem := v.Index(i)
+				elemSetter := func(nv reflect.Value) error {
+					if !elem.CanSet() {
+						return fmt.Errorf("element is not settable")
+					}
+					if err := checkAssignable(nv, elem.Type()); err != nil {
+						return err
+					}
+					elem.Set(nv)
+					return nil
+				}
+				newPath := settings.pathEncoder.Index(path, i)
+				childMeta, err := walkVisitRecursive(settings, visitor, elem, elemSetter, depth+1, visited, visitCount, newPath, reflect.StructTag(""), flatMap)
+				if err != nil {
+					return meta, err
+				}
+				if childMeta != nil {
+					meta.Children = append(meta.Children, childMeta)
+					childMeta.Parent = meta
+				}
+			}
+
+		case reflect.Map:
+			mapVal := v
+			for _, mapKey := range v.MapKeys() {
+				value := v.MapIndex(mapKey)
+				keyCopy := mapKey
+				mapSetter := func(nv reflect.Value) error {
+					if err := checkAssignable(nv, mapVal.Type().Elem()); err != nil {
+						return err
+					}
+					mapVal.SetMapIndex(keyCopy, nv)
+					return nil
+				}
+				newPath := settings.pathEncoder.MapKey(path, mapKey)
+				childMeta, err := walkVisitRecursive(settings, visitor, value, mapSetter, depth+1, visited, visitCount, newPath, reflect.StructTag(""), flatMap)
+				if err != nil {
+					return meta, err
+				}
+				if childMeta != nil {
+					meta.Children = append(meta.Children, childMeta)
+					childMeta.Parent = meta
+				}
+			}
+		}
+	}
+
+	if visitor.Leave != nil {
+		action, err := visitor.Leave(v, *meta)
+		if err != nil {
+			return meta, err
+		}
+		switch action.kind {
+		case actionKindBreak:
+			return meta, errWalkBroken
+		case actionKindReplace:
+			if setter == nil {
+				return meta, fmt.Errorf("gowalker: cannot replace %s: no settable location", path)
+			}
+			if err := setter(action.newValue); err != nil {
+				return meta, fmt.Errorf("gowalker: replace %s: %w", path, err)
+			}
+		}
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,201 @@
+package gowalker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type visitInner struct {
+	Token string `sensitive:"true"`
+	Count int
+}
+
+type visitOuter struct {
+	Name     string
+	Password string `sensitive:"true"`
+	Inner    visitInner
+}
+
+func TestWalkVisitRedactsSensitiveFields(t *testing.T) {
+	obj := &visitOuter{
+		Name:     "alice",
+		Password: "hunter2",
+		Inner:    visitInner{Token: "secret-token", Count: 3},
+	}
+
+	visitor := Visitor{
+		Enter: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			if meta.Tag.Get("sensitive") == "true" {
+				return ActionReplace(reflect.ValueOf("[REDACTED]")), nil
+			}
+			return ActionContinue, nil
+		},
+	}
+
+	_, _, err := WalkVisit(obj, visitor)
+	if err != nil {
+		t.Fatalf("WalkVisit() error = %v", err)
+	}
+
+	if obj.Password != "[REDACTED]" {
+		t.Errorf("Password = %q, want [REDACTED]", obj.Password)
+	}
+	if obj.Inner.Token != "[REDACTED]" {
+		t.Errorf("Inner.Token = %q, want [REDACTED]", obj.Inner.Token)
+	}
+	if obj.Name != "alice" {
+		t.Errorf("Name was mutated: %q", obj.Name)
+	}
+	if obj.Inner.Count != 3 {
+		t.Errorf("Inner.Count was mutated: %d", obj.Inner.Count)
+	}
+}
+
+func TestWalkVisitActionReplaceReturnsErrorOnTypeMismatch(t *testing.T) {
+	type withInt struct {
+		N int `sensitive:"true"`
+	}
+
+	visitor := Visitor{
+		Enter: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			if meta.Tag.Get("sensitive") == "true" {
+				return ActionReplace(reflect.ValueOf("not an int")), nil
+			}
+			return ActionContinue, nil
+		},
+	}
+
+	obj := &withInt{N: 5}
+	if _, _, err := WalkVisit(obj, visitor); err == nil {
+		t.Fatal("WalkVisit() error = nil, want error for a type-mismatched ActionReplace")
+	}
+	if obj.N != 5 {
+		t.Errorf("N = %d, want unchanged 5 after a rejected replace", obj.N)
+	}
+}
+
+func TestWalkVisitActionReplaceReturnsErrorOnTypeMismatchInMap(t *testing.T) {
+	m := map[string]int{"a": 1}
+
+	visitor := Visitor{
+		Enter: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			if v.Kind() == reflect.Int {
+				return ActionReplace(reflect.ValueOf("not an int")), nil
+			}
+			return ActionContinue, nil
+		},
+	}
+
+	if _, _, err := WalkVisit(&m, visitor); err == nil {
+		t.Fatal("WalkVisit() error = nil, want error for a type-mismatched map ActionReplace")
+	}
+	if m["a"] != 1 {
+		t.Errorf(`m["a"] = %d, want unchanged 1 after a rejected replace`, m["a"])
+	}
+}
+
+func TestWalkVisitEnterLeaveOrder(t *testing.T) {
+	type inner struct {
+		A int
+	}
+	type outer struct {
+		Inner inner
+	}
+
+	var events []string
+	visitor := Visitor{
+		Enter: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			events = append(events, "enter:"+meta.Path)
+			return ActionContinue, nil
+		},
+		Leave: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			events = append(events, "leave:"+meta.Path)
+			return ActionContinue, nil
+		},
+	}
+
+	_, _, err := WalkVisit(&outer{Inner: inner{A: 1}}, visitor)
+	if err != nil {
+		t.Fatalf("WalkVisit() error = %v", err)
+	}
+
+	leaveInner := -1
+	enterOuter := -1
+	leaveOuter := -1
+	for i, e := range events {
+		switch {
+		case enterOuter == -1 && strings.HasPrefix(e, "enter:") && strings.HasSuffix(e, ".*"):
+			enterOuter = i
+		case strings.HasPrefix(e, "leave:") && strings.HasSuffix(e, ".*.Inner"):
+			leaveInner = i
+		case strings.HasPrefix(e, "leave:") && strings.HasSuffix(e, ".*"):
+			leaveOuter = i
+		}
+	}
+	if enterOuter == -1 || leaveInner == -1 || leaveOuter == -1 {
+		t.Fatalf("missing expected events: %v", events)
+	}
+	if !(enterOuter < leaveInner && leaveInner < leaveOuter) {
+		t.Errorf("expected enter(outer) < leave(inner) < leave(outer), got %v", events)
+	}
+}
+
+func TestWalkVisitActionBreakStopsWalk(t *testing.T) {
+	type s struct {
+		A, B, C int
+	}
+
+	var visited []string
+	visitor := Visitor{
+		Enter: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			visited = append(visited, meta.Path)
+			if strings.HasSuffix(meta.Path, ".B") {
+				return ActionBreak, nil
+			}
+			return ActionContinue, nil
+		},
+	}
+
+	_, _, err := WalkVisit(&s{}, visitor)
+	if err != nil {
+		t.Fatalf("WalkVisit() error = %v", err)
+	}
+
+	for _, p := range visited {
+		if strings.HasSuffix(p, ".C") {
+			t.Fatalf("walk continued past ActionBreak: visited %v", visited)
+		}
+	}
+}
+
+func TestWalkVisitActionSkipDoesNotDescend(t *testing.T) {
+	type inner struct {
+		A int
+	}
+	type outer struct {
+		Inner inner
+	}
+
+	var visited []string
+	visitor := Visitor{
+		Enter: func(v reflect.Value, meta FieldMeta) (Action, error) {
+			visited = append(visited, meta.Path)
+			if strings.HasSuffix(meta.Path, ".Inner") {
+				return ActionSkip, nil
+			}
+			return ActionContinue, nil
+		},
+	}
+
+	_, _, err := WalkVisit(&outer{}, visitor)
+	if err != nil {
+		t.Fatalf("WalkVisit() error = %v", err)
+	}
+
+	for _, p := range visited {
+		if strings.HasSuffix(p, ".Inner.A") {
+			t.Fatalf("ActionSkip did not prevent descent: visited %v", visited)
+		}
+	}
+}